@@ -0,0 +1,44 @@
+package collision
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// Body is a collidable registered with a System. Position and Velocity are
+// pointers into the owning simulation body (e.g. a physics.Body) so that
+// resolution mutates the real simulation state directly, the same way
+// physics.World operates on *Body rather than copies.
+type Body struct {
+	Position *mgl32.Vec3
+	Velocity *mgl32.Vec3
+
+	InvMass    float32
+	Elasticity float32
+	Shape      Shape
+}
+
+// NewBody registers a dynamic body with finite mass.
+func NewBody(position, velocity *mgl32.Vec3, mass, elasticity float32, shape Shape) *Body {
+	return &Body{
+		Position:   position,
+		Velocity:   velocity,
+		InvMass:    1.0 / mass,
+		Elasticity: elasticity,
+		Shape:      shape,
+	}
+}
+
+// NewStaticBody registers an immovable body (e.g. a ground Plane) with
+// infinite mass: InvMass is zero, so impulses never move it.
+func NewStaticBody(position mgl32.Vec3, elasticity float32, shape Shape) *Body {
+	velocity := mgl32.Vec3{0, 0, 0}
+	return &Body{
+		Position:   &position,
+		Velocity:   &velocity,
+		InvMass:    0,
+		Elasticity: elasticity,
+		Shape:      shape,
+	}
+}
+
+func (b *Body) bounds() bounds {
+	return b.Shape.bounds(*b.Position)
+}
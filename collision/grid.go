@@ -0,0 +1,101 @@
+package collision
+
+import (
+	"math"
+	"sort"
+)
+
+type cellCoord struct {
+	x, y, z int32
+}
+
+// grid is a uniform spatial hash used for broad-phase collision detection.
+// Bodies are rebinned into cells every step; only bodies sharing a cell are
+// ever tested in narrow-phase. index records each body's position in the
+// slice passed to rebuild so candidatePairs can report pairs in a
+// deterministic order instead of map iteration order.
+type grid struct {
+	cellSize float32
+	cells    map[cellCoord][]*Body
+	index    map[*Body]int
+}
+
+func newGrid(cellSize float32) *grid {
+	return &grid{cellSize: cellSize, cells: make(map[cellCoord][]*Body), index: make(map[*Body]int)}
+}
+
+func (g *grid) rebuild(bodies []*Body) {
+	for k := range g.cells {
+		delete(g.cells, k)
+	}
+	for k := range g.index {
+		delete(g.index, k)
+	}
+
+	for i, b := range bodies {
+		g.index[b] = i
+		bb := b.bounds()
+		min := g.cellOf(bb.Min)
+		max := g.cellOf(bb.Max)
+
+		for x := min.x; x <= max.x; x++ {
+			for y := min.y; y <= max.y; y++ {
+				for z := min.z; z <= max.z; z++ {
+					c := cellCoord{x, y, z}
+					g.cells[c] = append(g.cells[c], b)
+				}
+			}
+		}
+	}
+}
+
+func (g *grid) cellOf(p [3]float32) cellCoord {
+	return cellCoord{
+		x: int32(math.Floor(float64(p[0] / g.cellSize))),
+		y: int32(math.Floor(float64(p[1] / g.cellSize))),
+		z: int32(math.Floor(float64(p[2] / g.cellSize))),
+	}
+}
+
+// candidatePairs returns every pair of bodies that share at least one grid
+// cell, deduplicated, for narrow-phase testing. Pairs are sorted by the
+// bodies' index in the slice last passed to rebuild, so resolution order
+// - and therefore the recorded trajectory - is the same on every run
+// regardless of map iteration order.
+func (g *grid) candidatePairs() [][2]*Body {
+	seen := make(map[[2]*Body]bool)
+	var pairs [][2]*Body
+
+	for _, bodies := range g.cells {
+		for i := 0; i < len(bodies); i++ {
+			for j := i + 1; j < len(bodies); j++ {
+				a, b := bodies[i], bodies[j]
+				if a == b {
+					continue
+				}
+				key := g.pairKey(a, b)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				pairs = append(pairs, key)
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if g.index[pairs[i][0]] != g.index[pairs[j][0]] {
+			return g.index[pairs[i][0]] < g.index[pairs[j][0]]
+		}
+		return g.index[pairs[i][1]] < g.index[pairs[j][1]]
+	})
+
+	return pairs
+}
+
+func (g *grid) pairKey(a, b *Body) [2]*Body {
+	if g.index[a] < g.index[b] {
+		return [2]*Body{a, b}
+	}
+	return [2]*Body{b, a}
+}
@@ -0,0 +1,98 @@
+package collision
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// resolvePair dispatches a candidate pair to the narrow-phase test for its
+// shape combination. Pairs involving an AABB are left untouched until
+// AABB narrow-phase is implemented.
+func resolvePair(a, b *Body) {
+	switch sa := a.Shape.(type) {
+	case Sphere:
+		switch sb := b.Shape.(type) {
+		case Sphere:
+			resolveSphereSphere(a, sa, b, sb)
+		case Plane:
+			resolveSpherePlane(a, sa, b, sb)
+		}
+	case Plane:
+		switch sb := b.Shape.(type) {
+		case Sphere:
+			resolveSpherePlane(b, sb, a, sa)
+		}
+	}
+}
+
+// resolveSphereSphere separates two overlapping spheres along their contact
+// normal and applies a restitution impulse split by inverse mass.
+func resolveSphereSphere(a *Body, sa Sphere, b *Body, sb Sphere) {
+	delta := b.Position.Sub(*a.Position)
+	dist := delta.Len()
+	penetration := sa.Radius + sb.Radius - dist
+	if penetration <= 0 {
+		return
+	}
+
+	normal := mgl32.Vec3{0, 1, 0}
+	if dist > 1e-6 {
+		normal = delta.Mul(1.0 / dist)
+	}
+
+	separate(a, b, normal, penetration)
+	applyImpulse(a, b, normal, min(a.Elasticity, b.Elasticity))
+}
+
+// resolveSpherePlane separates a sphere from an infinite plane and applies
+// a restitution impulse. plane is expected to be static (InvMass 0).
+func resolveSpherePlane(sphere *Body, s Sphere, plane *Body, p Plane) {
+	normal := p.Normal.Normalize()
+	dist := sphere.Position.Sub(*plane.Position).Dot(normal)
+	penetration := s.Radius - dist
+	if penetration <= 0 {
+		return
+	}
+
+	separate(plane, sphere, normal, penetration)
+	applyImpulse(plane, sphere, normal, min(plane.Elasticity, sphere.Elasticity))
+}
+
+// separate pushes a and b apart along normal (pointing from a to b),
+// proportional to each body's inverse mass so heavier/static bodies move
+// less (or not at all).
+func separate(a, b *Body, normal mgl32.Vec3, penetration float32) {
+	totalInvMass := a.InvMass + b.InvMass
+	if totalInvMass == 0 {
+		return
+	}
+
+	correction := normal.Mul(penetration / totalInvMass)
+	*a.Position = a.Position.Sub(correction.Mul(a.InvMass))
+	*b.Position = b.Position.Add(correction.Mul(b.InvMass))
+}
+
+// applyImpulse resolves the relative velocity of a and b along normal
+// (pointing from a to b) using j = -(1+e)*v_rel.n / (1/m1 + 1/m2).
+func applyImpulse(a, b *Body, normal mgl32.Vec3, restitution float32) {
+	totalInvMass := a.InvMass + b.InvMass
+	if totalInvMass == 0 {
+		return
+	}
+
+	relativeVelocity := b.Velocity.Sub(*a.Velocity)
+	velocityAlongNormal := relativeVelocity.Dot(normal)
+	if velocityAlongNormal > 0 {
+		return // already separating
+	}
+
+	j := -(1 + restitution) * velocityAlongNormal / totalInvMass
+	impulse := normal.Mul(j)
+
+	*a.Velocity = a.Velocity.Sub(impulse.Mul(a.InvMass))
+	*b.Velocity = b.Velocity.Add(impulse.Mul(b.InvMass))
+}
+
+func min(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,54 @@
+package collision
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// bounds is an axis-aligned box used only for broad-phase grid lookups; it
+// is distinct from the AABB Shape, which participates in narrow-phase too.
+type bounds struct {
+	Min, Max mgl32.Vec3
+}
+
+// Shape is anything a Body can collide as. Implementations report the
+// world-space bounds they occupy when centered at a given position, which
+// the broad-phase grid uses to find candidate pairs.
+type Shape interface {
+	bounds(center mgl32.Vec3) bounds
+}
+
+// Sphere is a body shape defined by its radius around the body's position.
+type Sphere struct {
+	Radius float32
+}
+
+func (s Sphere) bounds(center mgl32.Vec3) bounds {
+	r := mgl32.Vec3{s.Radius, s.Radius, s.Radius}
+	return bounds{Min: center.Sub(r), Max: center.Add(r)}
+}
+
+// Plane is an infinite static constraint surface passing through the
+// body's position, oriented by Normal. It is only meaningful on bodies
+// with infinite mass (see NewStaticBody).
+//
+// A plane's true extent can't be expressed as a finite bounds, so System
+// never puts Plane bodies in the broad-phase grid - it tests them directly
+// against every dynamic body instead (see System.Add). bounds is only
+// implemented here to satisfy the Shape interface and is never called.
+type Plane struct {
+	Normal mgl32.Vec3
+}
+
+func (p Plane) bounds(center mgl32.Vec3) bounds {
+	return bounds{Min: center, Max: center}
+}
+
+// AABB is a box shape defined by its half-extents around the body's
+// position. Broad-phase treats it like any other shape; narrow-phase
+// resolution against it is not yet implemented, so AABB-AABB and
+// AABB-Sphere pairs are reported as candidates but never resolved.
+type AABB struct {
+	HalfExtents mgl32.Vec3
+}
+
+func (a AABB) bounds(center mgl32.Vec3) bounds {
+	return bounds{Min: center.Sub(a.HalfExtents), Max: center.Add(a.HalfExtents)}
+}
@@ -0,0 +1,51 @@
+package collision
+
+// DefaultCellSize is a reasonable broad-phase cell size for body radii in
+// the 0.1-2 unit range typical of this simulation.
+const DefaultCellSize = 2.0
+
+// System is the collision subsystem: a broad-phase grid plus narrow-phase
+// resolution, run once per physics step over every registered Body.
+//
+// Plane bodies are kept out of the uniform grid entirely. A plane has
+// effectively infinite extent, so binning one into finite-sized cells would
+// mean iterating every cell the plane overlaps - unbounded work for a
+// shape that's only ever tested against a handful of dynamic bodies
+// anyway. Planes are instead tested directly against every grid body each
+// step.
+type System struct {
+	grid   *grid
+	bodies []*Body
+	planes []*Body
+}
+
+// NewSystem creates a collision System with the given broad-phase cell size.
+func NewSystem(cellSize float32) *System {
+	return &System{grid: newGrid(cellSize)}
+}
+
+// Add registers a body (dynamic or static) with the system. Plane bodies
+// are routed to the direct plane/body test instead of the broad-phase grid.
+func (s *System) Add(b *Body) {
+	if _, isPlane := b.Shape.(Plane); isPlane {
+		s.planes = append(s.planes, b)
+		return
+	}
+	s.bodies = append(s.bodies, b)
+}
+
+// Step rebuilds the broad-phase grid from current positions, resolves
+// every overlapping candidate pair it finds, then tests every registered
+// plane directly against every grid body.
+func (s *System) Step() {
+	s.grid.rebuild(s.bodies)
+	for _, pair := range s.grid.candidatePairs() {
+		resolvePair(pair[0], pair[1])
+	}
+
+	for _, plane := range s.planes {
+		for _, b := range s.bodies {
+			resolvePair(plane, b)
+		}
+	}
+}
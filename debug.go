@@ -0,0 +1,70 @@
+package main
+
+import (
+	"log"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// enableDebugOutput wires GL_ARB_debug_output / KHR_debug into Go's log
+// package, mirroring the pattern used by mature go-gl examples so GL
+// errors surface as structured, synchronous messages instead of requiring
+// manual gl.GetError() polling.
+func enableDebugOutput() {
+	gl.Enable(gl.DEBUG_OUTPUT)
+	gl.Enable(gl.DEBUG_OUTPUT_SYNCHRONOUS)
+	gl.DebugMessageCallback(debugCallback, nil)
+}
+
+func debugCallback(source, gltype, id, severity uint32, length int32, message string, userParam unsafe.Pointer) {
+	log.Printf("[gl debug] source=%s type=%s severity=%s: %s",
+		debugSourceString(source), debugTypeString(gltype), debugSeverityString(severity), message)
+}
+
+func debugSourceString(source uint32) string {
+	switch source {
+	case gl.DEBUG_SOURCE_API:
+		return "api"
+	case gl.DEBUG_SOURCE_WINDOW_SYSTEM:
+		return "window_system"
+	case gl.DEBUG_SOURCE_SHADER_COMPILER:
+		return "shader_compiler"
+	case gl.DEBUG_SOURCE_THIRD_PARTY:
+		return "third_party"
+	case gl.DEBUG_SOURCE_APPLICATION:
+		return "application"
+	default:
+		return "other"
+	}
+}
+
+func debugTypeString(gltype uint32) string {
+	switch gltype {
+	case gl.DEBUG_TYPE_ERROR:
+		return "error"
+	case gl.DEBUG_TYPE_DEPRECATED_BEHAVIOR:
+		return "deprecated"
+	case gl.DEBUG_TYPE_UNDEFINED_BEHAVIOR:
+		return "undefined_behavior"
+	case gl.DEBUG_TYPE_PORTABILITY:
+		return "portability"
+	case gl.DEBUG_TYPE_PERFORMANCE:
+		return "performance"
+	default:
+		return "other"
+	}
+}
+
+func debugSeverityString(severity uint32) string {
+	switch severity {
+	case gl.DEBUG_SEVERITY_HIGH:
+		return "high"
+	case gl.DEBUG_SEVERITY_MEDIUM:
+		return "medium"
+	case gl.DEBUG_SEVERITY_LOW:
+		return "low"
+	default:
+		return "notification"
+	}
+}
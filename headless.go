@@ -0,0 +1,102 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/asmitsharp/gravity-simulation/trajectory"
+)
+
+// runHeadless steps the demo scene for the given number of fixed physics
+// steps with no GLFW/GL involved at all, recording every body's state to
+// outPath. Because the integrator and timestep are fixed and the scene is
+// deterministic, two headless runs with the same steps produce a
+// bit-identical trajectory file.
+func runHeadless(steps int, outPath string) error {
+	world, objects, collisionSystem := newScene()
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	writer := trajectory.NewWriter(f)
+
+	for step := 0; step < steps; step++ {
+		world.Step(timeStep)
+		collisionSystem.Step()
+
+		frame := trajectory.Frame{Step: uint64(step), Bodies: make([]trajectory.BodyState, len(objects))}
+		for i, obj := range objects {
+			frame.Bodies[i] = trajectory.BodyState{
+				ID:       int32(i),
+				Position: obj.Position,
+				Velocity: obj.Velocity,
+			}
+		}
+		if err := writer.WriteFrame(frame); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("headless: wrote %d steps to %s", steps, outPath)
+	return nil
+}
+
+// runReplay opens window/GL exactly like runLive, but instead of stepping
+// physics it drives each frame's model matrices from a recorded
+// trajectory file, advancing one recorded frame per fixed-step tick.
+func runReplay(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := trajectory.NewReader(f)
+
+	win, err := newWindow()
+	if err != nil {
+		return err
+	}
+	defer win.Close()
+
+	var current trajectory.Frame
+	lastFrame := float32(glfw.GetTime())
+	var accumulator float32
+
+	for !win.window.ShouldClose() {
+		currentFrame := float32(glfw.GetTime())
+		frameTime := currentFrame - lastFrame
+		lastFrame = currentFrame
+		accumulator += frameTime
+
+		processInput(win.window, frameTime)
+		win.program.ReloadIfChanged()
+
+		for accumulator >= timeStep {
+			frame, err := reader.ReadFrame()
+			if err != nil {
+				break // trajectory exhausted; hold the last frame on screen
+			}
+			current = frame
+			accumulator -= timeStep
+		}
+
+		win.beginFrame()
+
+		models := make([]mgl32.Mat4, len(current.Bodies))
+		for i, b := range current.Bodies {
+			models[i] = mgl32.Translate3D(b.Position.X(), b.Position.Y(), b.Position.Z())
+		}
+		win.sphereRenderer.Draw(models)
+
+		win.endFrame()
+	}
+
+	return nil
+}
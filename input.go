@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// processInput handles per-frame key polling: escape to quit and WASD
+// movement via the package-level camera.
+func processInput(window *glfw.Window, dt float32) {
+	if window.GetKey(glfw.KeyEscape) == glfw.Press {
+		window.SetShouldClose(true)
+	}
+	camera.ProcessKeyboard(window, dt)
+}
+
+// framebufferSizeCallback keeps the GL viewport and projection aspect ratio
+// in sync with the window whenever it is resized.
+func framebufferSizeCallback(window *glfw.Window, width, height int) {
+	gl.Viewport(0, 0, int32(width), int32(height))
+	if height > 0 {
+		aspectRatio = float32(width) / float32(height)
+	}
+}
+
+// cursorPosCallback feeds raw cursor movement into the camera's mouse look.
+func cursorPosCallback(window *glfw.Window, xpos, ypos float64) {
+	camera.ProcessMouseMovement(float32(xpos), float32(ypos))
+}
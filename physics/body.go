@@ -0,0 +1,77 @@
+package physics
+
+import "github.com/go-gl/mathgl/mgl32"
+
+// Body is a point mass tracked by a World. It carries the minimal state
+// needed for gravitational accumulation and integration; rendering and
+// gameplay concerns live on top of it in the calling package.
+type Body struct {
+	Position mgl32.Vec3
+	Velocity mgl32.Vec3
+	Mass     float32
+
+	prevPosition mgl32.Vec3
+	forces       mgl32.Vec3
+	prevAccel    mgl32.Vec3
+}
+
+// NewBody creates a Body with the given initial position, velocity, and mass.
+func NewBody(position, velocity mgl32.Vec3, mass float32) *Body {
+	return &Body{
+		Position:     position,
+		Velocity:     velocity,
+		Mass:         mass,
+		prevPosition: position,
+	}
+}
+
+// AddForce accumulates a force to be applied on the next integration step.
+func (b *Body) AddForce(force mgl32.Vec3) {
+	b.forces = b.forces.Add(force)
+}
+
+// PrevPosition returns the position this body held before its most recent
+// integration step, letting callers interpolate render position between
+// fixed physics steps.
+func (b *Body) PrevPosition() mgl32.Vec3 {
+	return b.prevPosition
+}
+
+// integrate advances the body by dt using symplectic Euler and clears the
+// accumulated forces. It is called by World.Step once per body per step
+// when the world's integrator is SymplecticEuler.
+func (b *Body) integrate(dt float32) {
+	acceleration := b.forces.Mul(1.0 / b.Mass)
+	b.prevPosition = b.Position
+
+	b.Velocity = b.Velocity.Add(acceleration.Mul(dt))
+	b.Position = b.Position.Add(b.Velocity.Mul(dt))
+
+	b.forces = mgl32.Vec3{0, 0, 0}
+}
+
+// beginVerletStep is the first half of velocity Verlet: it advances
+// position using the current velocity and the acceleration from forces
+// already accumulated this step (a(t)), remembers that acceleration for
+// finishVerletStep, and clears forces so World can accumulate a(t+dt) at
+// the new position.
+func (b *Body) beginVerletStep(dt float32) {
+	acceleration := b.forces.Mul(1.0 / b.Mass)
+	b.prevPosition = b.Position
+	b.prevAccel = acceleration
+
+	b.Position = b.Position.Add(b.Velocity.Mul(dt)).Add(acceleration.Mul(0.5 * dt * dt))
+
+	b.forces = mgl32.Vec3{0, 0, 0}
+}
+
+// finishVerletStep is the second half of velocity Verlet: given a(t+dt)
+// freshly accumulated at the body's new position, it updates velocity
+// using the average of a(t) and a(t+dt) and clears forces.
+func (b *Body) finishVerletStep(dt float32) {
+	acceleration := b.forces.Mul(1.0 / b.Mass)
+	avgAccel := b.prevAccel.Add(acceleration).Mul(0.5)
+	b.Velocity = b.Velocity.Add(avgAccel.Mul(dt))
+
+	b.forces = mgl32.Vec3{0, 0, 0}
+}
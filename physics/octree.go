@@ -0,0 +1,201 @@
+package physics
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// minNodeWidth bounds how far insert will keep subdividing. Bodies at (or
+// numerically indistinguishable from) the same position would otherwise
+// always map to the same child, subdividing forever; once a node shrinks
+// to this width it becomes a bucket leaf instead and its bodies are force-
+// tested directly rather than further split.
+const minNodeWidth = 1e-4
+
+// octree is a Barnes-Hut spatial tree over a fixed set of bodies, rebuilt
+// once per World.Step. Each node covers a cube; leaves hold one or more
+// bodies (more than one only when they're coincident or the node has hit
+// minNodeWidth) and internal nodes cache the total mass and center of mass
+// of their subtree so distant clusters can be approximated as one point
+// mass.
+type octree struct {
+	center mgl32.Vec3
+	width  float32
+
+	mass         float32
+	centerOfMass mgl32.Vec3
+
+	bodies   []*Body // non-empty only on leaves
+	children [8]*octree
+}
+
+func buildOctree(bodies []*Body) *octree {
+	center, width := boundingCube(bodies)
+	root := &octree{center: center, width: width}
+	for _, b := range bodies {
+		root.insert(b)
+	}
+	return root
+}
+
+// boundingCube returns the center and width of the smallest cube containing
+// every body, padded slightly so bodies on the boundary still subdivide.
+func boundingCube(bodies []*Body) (mgl32.Vec3, float32) {
+	if len(bodies) == 0 {
+		return mgl32.Vec3{0, 0, 0}, 1
+	}
+
+	min, max := bodies[0].Position, bodies[0].Position
+	for _, b := range bodies[1:] {
+		for i := 0; i < 3; i++ {
+			if b.Position[i] < min[i] {
+				min[i] = b.Position[i]
+			}
+			if b.Position[i] > max[i] {
+				max[i] = b.Position[i]
+			}
+		}
+	}
+
+	extent := max.Sub(min)
+	width := extent[0]
+	if extent[1] > width {
+		width = extent[1]
+	}
+	if extent[2] > width {
+		width = extent[2]
+	}
+	if width <= 0 {
+		width = 1
+	}
+
+	center := min.Add(max).Mul(0.5)
+	return center, width * 1.01
+}
+
+func (n *octree) isLeaf() bool {
+	return n.children[0] == nil && n.children[1] == nil && n.children[2] == nil &&
+		n.children[3] == nil && n.children[4] == nil && n.children[5] == nil &&
+		n.children[6] == nil && n.children[7] == nil
+}
+
+func (n *octree) insert(b *Body) {
+	if n.isLeaf() {
+		if len(n.bodies) == 0 {
+			n.bodies = append(n.bodies, b)
+			n.mass = b.Mass
+			n.centerOfMass = b.Position
+			return
+		}
+
+		if n.width <= minNodeWidth {
+			n.bucket(b)
+			return
+		}
+
+		existing := n.bodies
+		n.bodies = nil
+		n.subdivide()
+		for _, e := range existing {
+			n.childFor(e.Position).insert(e)
+		}
+	}
+
+	n.childFor(b.Position).insert(b)
+	n.bucket(b)
+}
+
+// bucket folds b's mass into this node's running total and center of
+// mass, and - only once this node is a leaf too small to subdivide
+// further - appends it to the node's own body list so forceOn can test it
+// directly instead of approximating.
+func (n *octree) bucket(b *Body) {
+	totalMass := n.mass + b.Mass
+	n.centerOfMass = n.centerOfMass.Mul(n.mass).Add(b.Position.Mul(b.Mass)).Mul(1.0 / totalMass)
+	n.mass = totalMass
+
+	if n.isLeaf() {
+		n.bodies = append(n.bodies, b)
+	}
+}
+
+func (n *octree) subdivide() {
+	half := n.width / 2
+	quarter := half / 2
+	for i := 0; i < 8; i++ {
+		offset := mgl32.Vec3{quarter, quarter, quarter}
+		if i&1 != 0 {
+			offset[0] = -quarter
+		}
+		if i&2 != 0 {
+			offset[1] = -quarter
+		}
+		if i&4 != 0 {
+			offset[2] = -quarter
+		}
+		n.children[i] = &octree{center: n.center.Add(offset), width: half}
+	}
+}
+
+func (n *octree) childFor(pos mgl32.Vec3) *octree {
+	index := 0
+	if pos.X() < n.center.X() {
+		index |= 1
+	}
+	if pos.Y() < n.center.Y() {
+		index |= 2
+	}
+	if pos.Z() < n.center.Z() {
+		index |= 4
+	}
+	return n.children[index]
+}
+
+// forceOn returns the Barnes-Hut approximated gravitational force exerted by
+// this node's subtree on body b, recursing into children whenever the node
+// is too close (width/distance > theta) to be treated as a single mass.
+// Leaves are always resolved as a direct pairwise sum over their bucketed
+// bodies (normally just one) rather than the aggregate mass/COM
+// approximation, since a leaf can hold several coincident bodies and the
+// aggregate would otherwise include b's own mass when b is one of them.
+func (n *octree) forceOn(b *Body, g, softening, theta float32) mgl32.Vec3 {
+	if n == nil || n.mass == 0 {
+		return mgl32.Vec3{0, 0, 0}
+	}
+
+	if n.isLeaf() {
+		total := mgl32.Vec3{0, 0, 0}
+		for _, other := range n.bodies {
+			if other == b {
+				continue
+			}
+			total = total.Add(pointForce(b, other.Position, other.Mass, g, softening))
+		}
+		return total
+	}
+
+	r := n.centerOfMass.Sub(b.Position)
+	distSq := r.Dot(r) + softening*softening
+	dist := float32(math.Sqrt(float64(distSq)))
+
+	if n.width/dist < theta {
+		return pointForce(b, n.centerOfMass, n.mass, g, softening)
+	}
+
+	total := mgl32.Vec3{0, 0, 0}
+	for _, child := range n.children {
+		total = total.Add(child.forceOn(b, g, softening, theta))
+	}
+	return total
+}
+
+// pointForce returns the gravitational force Newton's law predicts on b
+// from a point mass of the given mass at pos.
+func pointForce(b *Body, pos mgl32.Vec3, mass, g, softening float32) mgl32.Vec3 {
+	r := pos.Sub(b.Position)
+	distSq := r.Dot(r) + softening*softening
+	dist := float32(math.Sqrt(float64(distSq)))
+	magnitude := g * b.Mass * mass / (distSq * dist)
+	return r.Mul(magnitude)
+}
@@ -0,0 +1,99 @@
+package physics
+
+// Integrator selects the numerical scheme World.Step uses to advance bodies.
+type Integrator int
+
+const (
+	// SymplecticEuler updates velocity from the current force before using
+	// the updated velocity to update position. Cheap and stable for orbits.
+	SymplecticEuler Integrator = iota
+	// VelocityVerlet evaluates acceleration both before and after the
+	// position update and advances velocity with their average
+	// (v += 0.5*(a(t)+a(t+dt))*dt). It costs a second force accumulation
+	// pass per step but conserves energy better over long runs.
+	VelocityVerlet
+)
+
+const (
+	// DefaultG is the gravitational constant used when a World is created
+	// with NewWorld. It is expressed in simulation units, not SI units, so
+	// that orbits are visible on screen without extreme scaling.
+	DefaultG = 1.0
+	// DefaultSoftening avoids force singularities when two bodies get very
+	// close together.
+	DefaultSoftening = 0.05
+	// DefaultTheta is the Barnes-Hut accuracy/speed tradeoff: lower values
+	// recurse more aggressively and are more accurate but slower.
+	DefaultTheta = 0.5
+)
+
+// World owns every Body participating in the simulation and advances them
+// together under mutual gravity each Step.
+type World struct {
+	G          float32
+	Softening  float32
+	Theta      float32
+	Integrator Integrator
+
+	bodies []*Body
+}
+
+// NewWorld creates an empty World with the default gravitational constant,
+// softening factor, Barnes-Hut theta, and a symplectic Euler integrator.
+func NewWorld() *World {
+	return &World{
+		G:          DefaultG,
+		Softening:  DefaultSoftening,
+		Theta:      DefaultTheta,
+		Integrator: SymplecticEuler,
+		bodies:     make([]*Body, 0),
+	}
+}
+
+// AddBody registers a body so it participates in future Step calls.
+func (w *World) AddBody(b *Body) {
+	w.bodies = append(w.bodies, b)
+}
+
+// Bodies returns every body currently in the world, in insertion order.
+func (w *World) Bodies() []*Body {
+	return w.bodies
+}
+
+// Step builds a Barnes-Hut octree over the current body positions, applies
+// the resulting gravitational forces to each body, and integrates every
+// body forward by dt using the configured Integrator.
+//
+// VelocityVerlet needs acceleration both before and after the position
+// update, so it rebuilds the octree and re-accumulates forces at the new
+// positions partway through the step; SymplecticEuler only needs the one
+// pass done up front.
+func (w *World) Step(dt float32) {
+	if len(w.bodies) == 0 {
+		return
+	}
+
+	w.accumulateForces()
+
+	if w.Integrator == VelocityVerlet {
+		for _, b := range w.bodies {
+			b.beginVerletStep(dt)
+		}
+		w.accumulateForces()
+		for _, b := range w.bodies {
+			b.finishVerletStep(dt)
+		}
+		return
+	}
+
+	for _, b := range w.bodies {
+		b.integrate(dt)
+	}
+}
+
+func (w *World) accumulateForces() {
+	tree := buildOctree(w.bodies)
+	for _, b := range w.bodies {
+		b.AddForce(tree.forceOn(b, w.G, w.Softening, w.Theta))
+	}
+}
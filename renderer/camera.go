@@ -0,0 +1,109 @@
+package renderer
+
+import (
+	"math"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	defaultYaw         = -90.0
+	defaultPitch       = 0.0
+	defaultSpeed       = 4.0
+	defaultSensitivity = 0.1
+	maxPitch           = 89.0
+)
+
+// Camera is a free-fly camera driven by WASD movement and mouse look,
+// matching the behavior of a standard first-person OpenGL camera.
+type Camera struct {
+	Position mgl32.Vec3
+	Front    mgl32.Vec3
+	Up       mgl32.Vec3
+	right    mgl32.Vec3
+	worldUp  mgl32.Vec3
+
+	Yaw, Pitch         float32
+	Speed, Sensitivity float32
+
+	lastX, lastY float32
+	firstMouse   bool
+}
+
+// NewCamera creates a camera positioned at position, looking down -Z.
+func NewCamera(position mgl32.Vec3) *Camera {
+	c := &Camera{
+		Position:    position,
+		worldUp:     mgl32.Vec3{0, 1, 0},
+		Yaw:         defaultYaw,
+		Pitch:       defaultPitch,
+		Speed:       defaultSpeed,
+		Sensitivity: defaultSensitivity,
+		firstMouse:  true,
+	}
+	c.updateVectors()
+	return c
+}
+
+// ViewMatrix returns the current look-at matrix for this camera.
+func (c *Camera) ViewMatrix() mgl32.Mat4 {
+	return mgl32.LookAtV(c.Position, c.Position.Add(c.Front), c.Up)
+}
+
+// ProcessKeyboard advances the camera's position along its own basis
+// vectors according to which WASD keys are currently held.
+func (c *Camera) ProcessKeyboard(window *glfw.Window, dt float32) {
+	velocity := c.Speed * dt
+
+	if window.GetKey(glfw.KeyW) == glfw.Press {
+		c.Position = c.Position.Add(c.Front.Mul(velocity))
+	}
+	if window.GetKey(glfw.KeyS) == glfw.Press {
+		c.Position = c.Position.Sub(c.Front.Mul(velocity))
+	}
+	if window.GetKey(glfw.KeyA) == glfw.Press {
+		c.Position = c.Position.Sub(c.right.Mul(velocity))
+	}
+	if window.GetKey(glfw.KeyD) == glfw.Press {
+		c.Position = c.Position.Add(c.right.Mul(velocity))
+	}
+}
+
+// ProcessMouseMovement updates yaw/pitch from an absolute cursor position,
+// as reported by a glfw.CursorPosCallback.
+func (c *Camera) ProcessMouseMovement(xpos, ypos float32) {
+	if c.firstMouse {
+		c.lastX, c.lastY = xpos, ypos
+		c.firstMouse = false
+	}
+
+	xoffset := (xpos - c.lastX) * c.Sensitivity
+	yoffset := (c.lastY - ypos) * c.Sensitivity
+	c.lastX, c.lastY = xpos, ypos
+
+	c.Yaw += xoffset
+	c.Pitch += yoffset
+	if c.Pitch > maxPitch {
+		c.Pitch = maxPitch
+	}
+	if c.Pitch < -maxPitch {
+		c.Pitch = -maxPitch
+	}
+
+	c.updateVectors()
+}
+
+func (c *Camera) updateVectors() {
+	yaw := mgl32.DegToRad(c.Yaw)
+	pitch := mgl32.DegToRad(c.Pitch)
+
+	front := mgl32.Vec3{
+		float32(math.Cos(float64(pitch)) * math.Cos(float64(yaw))),
+		float32(math.Sin(float64(pitch))),
+		float32(math.Cos(float64(pitch)) * math.Sin(float64(yaw))),
+	}
+	c.Front = front.Normalize()
+	c.right = c.Front.Cross(c.worldUp).Normalize()
+	c.Up = c.right.Cross(c.Front).Normalize()
+}
@@ -0,0 +1,40 @@
+package renderer
+
+import "math"
+
+// generateSphere builds a UV sphere with the given number of latitude
+// (stacks) and longitude (slices) subdivisions. Vertices are packed as
+// interleaved position + normal floats; indices describe triangle list
+// winding suitable for gl.DrawElementsInstanced.
+func generateSphere(stacks, slices int) (vertices []float32, indices []uint32) {
+	for stack := 0; stack <= stacks; stack++ {
+		phi := math.Pi * (float64(stack) / float64(stacks))
+		for slice := 0; slice <= slices; slice++ {
+			theta := 2 * math.Pi * (float64(slice) / float64(slices))
+
+			x := math.Sin(phi) * math.Cos(theta)
+			y := math.Cos(phi)
+			z := math.Sin(phi) * math.Sin(theta)
+
+			// Unit sphere: position and normal are the same vector.
+			vertices = append(vertices,
+				float32(x), float32(y), float32(z),
+				float32(x), float32(y), float32(z),
+			)
+		}
+	}
+
+	verticesPerRow := uint32(slices + 1)
+	for stack := 0; stack < stacks; stack++ {
+		for slice := 0; slice < slices; slice++ {
+			a := uint32(stack)*verticesPerRow + uint32(slice)
+			b := a + verticesPerRow
+			indices = append(indices,
+				a, b, a+1,
+				a+1, b, b+1,
+			)
+		}
+	}
+
+	return vertices, indices
+}
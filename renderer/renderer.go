@@ -0,0 +1,102 @@
+package renderer
+
+import (
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	sphereStacks = 16
+	sphereSlices = 16
+
+	vertexAttrPosition = 0
+	vertexAttrNormal   = 1
+	// A mat4 instance attribute consumes four consecutive vec4 locations.
+	instanceAttrModel = 2
+
+	maxInstances = 4096
+)
+
+// Renderer draws every body in a single gl.DrawElementsInstanced call. It
+// owns one sphere mesh, shared by all instances, and a per-frame instance
+// buffer of model matrices uploaded before drawing.
+type Renderer struct {
+	vao         uint32
+	vbo         uint32
+	ebo         uint32
+	instanceVBO uint32
+	indexCount  int32
+}
+
+// New uploads the shared sphere mesh and allocates the instance buffer.
+// The program is expected to declare `layout(location = 0) in vec3 aPos`,
+// `layout(location = 1) in vec3 aNormal`, and four consecutive vec4
+// locations starting at 2 for the per-instance model matrix.
+func New() *Renderer {
+	vertices, indices := generateSphere(sphereStacks, sphereSlices)
+
+	r := &Renderer{indexCount: int32(len(indices))}
+
+	gl.GenVertexArrays(1, &r.vao)
+	gl.BindVertexArray(r.vao)
+
+	gl.GenBuffers(1, &r.vbo)
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, gl.Ptr(vertices), gl.STATIC_DRAW)
+
+	stride := int32(6 * 4)
+	gl.VertexAttribPointer(vertexAttrPosition, 3, gl.FLOAT, false, stride, gl.PtrOffset(0))
+	gl.EnableVertexAttribArray(vertexAttrPosition)
+	gl.VertexAttribPointer(vertexAttrNormal, 3, gl.FLOAT, false, stride, gl.PtrOffset(3*4))
+	gl.EnableVertexAttribArray(vertexAttrNormal)
+
+	gl.GenBuffers(1, &r.ebo)
+	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, r.ebo)
+	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, len(indices)*4, gl.Ptr(indices), gl.STATIC_DRAW)
+
+	gl.GenBuffers(1, &r.instanceVBO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.instanceVBO)
+	gl.BufferData(gl.ARRAY_BUFFER, maxInstances*16*4, nil, gl.DYNAMIC_DRAW)
+
+	matSize := int32(16 * 4)
+	vec4Size := int32(4 * 4)
+	for column := uint32(0); column < 4; column++ {
+		loc := instanceAttrModel + column
+		gl.VertexAttribPointer(loc, 4, gl.FLOAT, false, matSize, gl.PtrOffset(int(column*uint32(vec4Size))))
+		gl.EnableVertexAttribArray(loc)
+		gl.VertexAttribDivisor(loc, 1)
+	}
+
+	gl.BindVertexArray(0)
+
+	return r
+}
+
+// Draw uploads the given model matrices as per-instance data and issues a
+// single instanced draw call for the whole batch. Matrices beyond the
+// first maxInstances are not drawn.
+func (r *Renderer) Draw(models []mgl32.Mat4) {
+	if len(models) == 0 {
+		return
+	}
+	if len(models) > maxInstances {
+		models = models[:maxInstances]
+	}
+
+	gl.BindVertexArray(r.vao)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.instanceVBO)
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, len(models)*16*4, gl.Ptr(models))
+
+	gl.DrawElementsInstanced(gl.TRIANGLES, r.indexCount, gl.UNSIGNED_INT, nil, int32(len(models)))
+
+	gl.BindVertexArray(0)
+}
+
+// Delete releases the GPU buffers owned by the renderer.
+func (r *Renderer) Delete() {
+	gl.DeleteBuffers(1, &r.instanceVBO)
+	gl.DeleteBuffers(1, &r.ebo)
+	gl.DeleteBuffers(1, &r.vbo)
+	gl.DeleteVertexArrays(1, &r.vao)
+}
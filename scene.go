@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/asmitsharp/gravity-simulation/collision"
+	"github.com/asmitsharp/gravity-simulation/physics"
+)
+
+// newScene builds the demo three-body configuration shared by live
+// rendering and headless simulation, so both modes step the exact same
+// deterministic setup. Replay mode does not use it; it draws recorded
+// positions only.
+func newScene() (*physics.World, []*Object, *collision.System) {
+	world := physics.NewWorld()
+	objects := []*Object{
+		NewObject(mgl32.Vec3{0, 0, 0}, mgl32.Vec3{0, 0, 0}, 50.0, 0.5, 0.8),
+		NewObject(mgl32.Vec3{2, 0, 0}, mgl32.Vec3{0, 0, 1.5}, 1.0, 0.2, 0.8),
+		NewObject(mgl32.Vec3{-2, 0, 0}, mgl32.Vec3{0, 0, -1.5}, 1.0, 0.2, 0.8),
+	}
+
+	collisionSystem := collision.NewSystem(collision.DefaultCellSize)
+	collisionSystem.Add(collision.NewStaticBody(mgl32.Vec3{0, -1, 0}, 0.8, collision.Plane{Normal: mgl32.Vec3{0, 1, 0}}))
+	for _, obj := range objects {
+		world.AddBody(obj.Body)
+		collisionSystem.Add(collision.NewBody(&obj.Position, &obj.Velocity, obj.Mass, obj.Elasticity, collision.Sphere{Radius: obj.Radius}))
+	}
+
+	return world, objects, collisionSystem
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+func compileAndLinkProgram(vertexShaderPath, fragmentShaderPath string) (uint32, error) {
+	vertexShaderSource, err := ioutil.ReadFile(vertexShaderPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read vertex shader: %v", err)
+	}
+
+	fragmentShaderSource, err := ioutil.ReadFile(fragmentShaderPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read fragment shader: %v", err)
+	}
+
+	vertexShader, err := compileShader(string(vertexShaderSource)+"\x00", gl.VERTEX_SHADER)
+	if err != nil {
+		return 0, err
+	}
+
+	fragmentShader, err := compileShader(string(fragmentShaderSource)+"\x00", gl.FRAGMENT_SHADER)
+	if err != nil {
+		return 0, err
+	}
+
+	program := gl.CreateProgram()
+	gl.AttachShader(program, vertexShader)
+	gl.AttachShader(program, fragmentShader)
+	gl.LinkProgram(program)
+
+	var status int32
+	gl.GetProgramiv(program, gl.LINK_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetProgramiv(program, gl.INFO_LOG_LENGTH, &logLength)
+		log := make([]byte, logLength)
+		gl.GetProgramInfoLog(program, logLength, nil, &log[0])
+		return 0, fmt.Errorf("failed to link program: %s", log)
+	}
+
+	gl.DeleteShader(vertexShader)
+	gl.DeleteShader(fragmentShader)
+
+	return program, nil
+}
+
+func compileShader(source string, shaderType uint32) (uint32, error) {
+	shader := gl.CreateShader(shaderType)
+
+	csources, free := gl.Strs(source)
+	gl.ShaderSource(shader, 1, csources, nil)
+	free()
+	gl.CompileShader(shader)
+
+	var status int32
+	gl.GetShaderiv(shader, gl.COMPILE_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetShaderiv(shader, gl.INFO_LOG_LENGTH, &logLength)
+		log := make([]byte, logLength)
+		gl.GetShaderInfoLog(shader, logLength, nil, &log[0])
+		return 0, fmt.Errorf("failed to compile %v: %s", source, log)
+	}
+
+	return shader, nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+
+	"github.com/asmitsharp/gravity-simulation/renderer"
+)
+
+// window bundles the GLFW/GL state shared by live rendering and replay: a
+// GLFW window, a hot-reloadable shader program, the instanced sphere
+// renderer, and the view/projection uniform locations. programID tracks
+// which program handle viewLoc/projLoc were fetched from, so beginFrame
+// can detect a shader hot-reload and re-fetch them.
+type window struct {
+	window         *glfw.Window
+	program        *Program
+	sphereRenderer *renderer.Renderer
+	programID      uint32
+	viewLoc        int32
+	projLoc        int32
+}
+
+// newWindow performs the GLFW/GL setup shared by live rendering and
+// replay: window creation, input callbacks, debug output, and loading the
+// shader program and sphere mesh.
+func newWindow() (*window, error) {
+	if err := glfw.Init(); err != nil {
+		return nil, fmt.Errorf("failed to initialize glfw: %w", err)
+	}
+
+	glfw.WindowHint(glfw.Resizable, glfw.True)
+	glfw.WindowHint(glfw.ContextVersionMajor, 4)
+	glfw.WindowHint(glfw.ContextVersionMinor, 1)
+	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+	glfwWindow, err := glfw.CreateWindow(windowWidth, windowHeight, windowTitle, nil, nil)
+	if err != nil {
+		glfw.Terminate()
+		return nil, fmt.Errorf("failed to create window: %w", err)
+	}
+	glfwWindow.MakeContextCurrent()
+	glfwWindow.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+	glfwWindow.SetFramebufferSizeCallback(framebufferSizeCallback)
+	glfwWindow.SetCursorPosCallback(cursorPosCallback)
+
+	if err := gl.Init(); err != nil {
+		glfw.Terminate()
+		return nil, fmt.Errorf("failed to initialize glow: %w", err)
+	}
+	gl.Enable(gl.DEPTH_TEST)
+	enableDebugOutput()
+
+	log.Println("OpenGL version", gl.GoStr(gl.GetString(gl.VERSION)))
+
+	program, err := NewProgram("shaders/vertex_shader.glsl", "shaders/fragment_shader.glsl")
+	if err != nil {
+		glfw.Terminate()
+		return nil, err
+	}
+	gl.UseProgram(program.ID())
+
+	w := &window{
+		window:         glfwWindow,
+		program:        program,
+		sphereRenderer: renderer.New(),
+	}
+	w.refreshUniformLocations()
+	return w, nil
+}
+
+// refreshUniformLocations re-fetches the view/projection uniform locations
+// from the program's current handle and records that handle in programID.
+func (w *window) refreshUniformLocations() {
+	w.programID = w.program.ID()
+	w.viewLoc = w.program.UniformLocation("view")
+	w.projLoc = w.program.UniformLocation("projection")
+}
+
+// beginFrame clears the framebuffer, activates the program, and uploads
+// the current view/projection matrices. If ReloadIfChanged swapped in a
+// new program handle since the last frame, the cached uniform locations
+// are re-fetched first - they don't carry over across a relink.
+func (w *window) beginFrame() {
+	if w.program.ID() != w.programID {
+		w.refreshUniformLocations()
+	}
+
+	gl.ClearColor(0.2, 0.3, 0.3, 1.0)
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+	gl.UseProgram(w.program.ID())
+
+	view := camera.ViewMatrix()
+	projection := mgl32.Perspective(mgl32.DegToRad(fov), aspectRatio, nearPlane, farPlane)
+	gl.UniformMatrix4fv(w.viewLoc, 1, false, &view[0])
+	gl.UniformMatrix4fv(w.projLoc, 1, false, &projection[0])
+}
+
+// endFrame swaps buffers and polls window events.
+func (w *window) endFrame() {
+	w.window.SwapBuffers()
+	glfw.PollEvents()
+}
+
+// Close releases the renderer and terminates GLFW.
+func (w *window) Close() {
+	w.sphereRenderer.Delete()
+	glfw.Terminate()
+}